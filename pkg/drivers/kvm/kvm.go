@@ -0,0 +1,100 @@
+// +build linux
+
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kvm
+
+import (
+	"fmt"
+
+	"github.com/docker/machine/libmachine/drivers"
+	"github.com/docker/machine/libmachine/mcnflag"
+)
+
+// defaultPrivateNetworkName is the name minikube gives the private KVM
+// network it creates and manages itself.
+const defaultPrivateNetworkName = "minikube-net"
+
+const (
+	flagNetworkMode = "kvm-network-mode"
+	flagBridgeName  = "kvm-bridge-name"
+	flagStaticIP    = "kvm-static-ip"
+)
+
+// Driver holds the state minikube needs to create and manage a KVM domain.
+type Driver struct {
+	*drivers.BaseDriver
+
+	// ConnectionURI is the libvirt connection URI used to reach the hypervisor.
+	ConnectionURI string
+
+	// Network is the pre-existing libvirt network (typically "default") that
+	// provides the VM with outbound/internet connectivity.
+	Network string
+	// PrivateNetwork is the minikube-managed NAT network used for host<->VM
+	// communication, keyed by PrivateMAC.
+	PrivateNetwork string
+	// PrivateMAC is the MAC address minikube generates for the VM's
+	// interface, used to look up its IP regardless of network mode.
+	PrivateMAC string
+
+	// NetworkMode selects how the domain's public interface is attached:
+	// "nat" (default), "bridge", "macvtap", or "open". See NetworkMode in
+	// network.go.
+	NetworkMode string
+	// BridgeName is the host bridge (bridge mode) or physical device
+	// (macvtap mode) the domain's public interface attaches to. Required
+	// whenever NetworkMode is anything other than "nat".
+	BridgeName string
+	// StaticIP, if set, pins the VM to this address instead of the default
+	// offset (staticHostOffset) within the private subnet.
+	StaticIP string
+}
+
+// GetCreateFlags registers the CLI flags this driver understands so they can
+// be set via `minikube start --kvm-...`.
+func (d *Driver) GetCreateFlags() []mcnflag.Flag {
+	return []mcnflag.Flag{
+		mcnflag.StringFlag{
+			Name:  flagNetworkMode,
+			Usage: "The KVM network mode to attach the VM's public interface with: nat, bridge, macvtap, or open",
+			Value: string(NetworkModeNAT),
+		},
+		mcnflag.StringFlag{
+			Name:  flagBridgeName,
+			Usage: "The host bridge (bridge mode) or device (macvtap mode) to attach the VM to; required unless --kvm-network-mode=nat",
+		},
+		mcnflag.StringFlag{
+			Name:  flagStaticIP,
+			Usage: "A static IP to assign the VM within its private subnet, instead of the default predictable offset",
+		},
+	}
+}
+
+// SetConfigFromFlags reads the flags registered in GetCreateFlags back onto
+// the driver.
+func (d *Driver) SetConfigFromFlags(flags drivers.DriverOptions) error {
+	d.NetworkMode = flags.String(flagNetworkMode)
+	d.BridgeName = flags.String(flagBridgeName)
+	d.StaticIP = flags.String(flagStaticIP)
+
+	if NetworkMode(d.NetworkMode).usesHostNetwork() && d.BridgeName == "" {
+		return fmt.Errorf("--%s is required when --%s=%s", flagBridgeName, flagNetworkMode, d.NetworkMode)
+	}
+
+	return nil
+}
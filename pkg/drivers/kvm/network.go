@@ -19,47 +19,150 @@ limitations under the License.
 package kvm
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"encoding/xml"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"os/exec"
 	"strings"
-	"text/template"
+	"sync"
 	"time"
 
 	"github.com/docker/machine/libmachine/log"
 	libvirt "github.com/libvirt/libvirt-go"
+	libvirtxml "github.com/libvirt/libvirt-go-xml"
 	"github.com/pkg/errors"
 	"k8s.io/minikube/pkg/network"
 	"k8s.io/minikube/pkg/util/retry"
 )
 
-// Replace with hardcoded range with CIDR
-// https://play.golang.org/p/m8TNTtygK0
-const networkTmpl = `
-<network>
-  <name>{{.Name}}</name>
-  <dns enable='no'/>
-  {{with .Parameters}}
-  <ip address='{{.Gateway}}' netmask='{{.Netmask}}'>
-    <dhcp>
-      <range start='{{.ClientMin}}' end='{{.ClientMax}}'/>
-    </dhcp>
-  </ip>
-  {{end}}
-</network>
-`
-
-type kvmNetwork struct {
-	Name string
-	network.Parameters
-}
-
 // firstSubnetAddr is starting subnet to try for new KVM cluster,
 // avoiding possible conflict with other local networks by further incrementing it up to 20 times by 10.
 const firstSubnetAddr = "192.168.39.0"
 
+// staticHostOffset is the offset within the private subnet reserved for the
+// minikube VM's DHCP lease (e.g. 192.168.39.2) when the user hasn't supplied
+// their own address via --kvm-static-ip.
+const staticHostOffset = 2
+
+// reservedHostIP returns the address minikube pins to d.PrivateMAC: either
+// the user-supplied --kvm-static-ip, or staticHostOffset within subnet.
+func (d *Driver) reservedHostIP(subnet *network.Parameters) (string, error) {
+	if d.StaticIP != "" {
+		return d.StaticIP, nil
+	}
+	gateway := net.ParseIP(subnet.Gateway).To4()
+	if gateway == nil {
+		return "", fmt.Errorf("parsing gateway address %s", subnet.Gateway)
+	}
+	host := make(net.IP, len(gateway))
+	copy(host, gateway)
+	host[3] = staticHostOffset
+	return host.String(), nil
+}
+
+// NetworkMode selects how the domain's "public" interface is attached,
+// controlled by the --kvm-network-mode flag.
+type NetworkMode string
+
+const (
+	// NetworkModeNAT is the default: a minikube-managed NAT network, as created by createNetwork.
+	NetworkModeNAT NetworkMode = "nat"
+	// NetworkModeBridge attaches the domain directly to an existing host bridge (--kvm-bridge-name).
+	NetworkModeBridge NetworkMode = "bridge"
+	// NetworkModeMacvtap attaches the domain to a host interface via macvtap in bridge mode.
+	NetworkModeMacvtap NetworkMode = "macvtap"
+	// NetworkModeOpen is an alias for NetworkModeBridge: it attaches the domain to the host bridge the same way.
+	NetworkModeOpen NetworkMode = "open"
+)
+
+// usesHostNetwork reports whether mode attaches the domain directly to a host
+// network device instead of minikube's own NAT network.
+func (m NetworkMode) usesHostNetwork() bool {
+	return m == NetworkModeBridge || m == NetworkModeMacvtap || m == NetworkModeOpen
+}
+
+// publicInterfaceXML returns the <interface> XML fragment for the domain's
+// "public" interface in bridge/macvtap mode, carrying d.PrivateMAC so that
+// lookupIP's guest-agent/ARP-scan fallbacks can find it by MAC the same way
+// they would a NAT-mode interface. Returns an empty string in NAT mode,
+// since that interface is instead described by the network's own XML.
+//
+// DomainInterface has no standalone Marshal method of its own (only
+// libvirtxml's root document types do), so the fragment is built directly,
+// the same way reserveHostInNetwork builds its <host> fragment.
+func (d *Driver) publicInterfaceXML() (string, error) {
+	switch NetworkMode(d.NetworkMode) {
+	case NetworkModeBridge, NetworkModeOpen:
+		if d.BridgeName == "" {
+			return "", fmt.Errorf("--kvm-bridge-name is required in %s network mode", d.NetworkMode)
+		}
+		return fmt.Sprintf(
+			"<interface type='bridge'><source bridge=%q/><mac address=%q/><model type='virtio'/></interface>",
+			d.BridgeName, d.PrivateMAC,
+		), nil
+	case NetworkModeMacvtap:
+		if d.BridgeName == "" {
+			return "", fmt.Errorf("--kvm-bridge-name is required in macvtap network mode")
+		}
+		return fmt.Sprintf(
+			"<interface type='direct'><source dev=%q mode='bridge'/><mac address=%q/><model type='virtio'/></interface>",
+			d.BridgeName, d.PrivateMAC,
+		), nil
+	default:
+		return "", nil
+	}
+}
+
+// ensurePublicInterface attaches the bridge/macvtap public interface to the
+// already-defined domain, persisted in its config and, if the domain is
+// running, live as well, if it isn't already attached.
+// This is what actually wires publicInterfaceXML's fragment onto the VM;
+// without it, --kvm-network-mode=bridge/macvtap would only change lookupIP's
+// bookkeeping without ever attaching the domain to the host bridge.
+func (d *Driver) ensurePublicInterface(conn *libvirt.Connect) error {
+	ifaceXML, err := d.publicInterfaceXML()
+	if err != nil {
+		return err
+	}
+	if ifaceXML == "" {
+		return nil
+	}
+
+	dom, err := conn.LookupDomainByName(d.MachineName)
+	if err != nil {
+		return errors.Wrap(err, "looking up domain by name")
+	}
+	defer func() { _ = dom.Free() }()
+
+	xmlDesc, err := dom.GetXMLDesc(0)
+	if err != nil {
+		return errors.Wrap(err, "getting domain xml")
+	}
+	def := libvirtxml.Domain{}
+	if err := def.Unmarshal(xmlDesc); err != nil {
+		return errors.Wrap(err, "unmarshalling domain xml")
+	}
+	for _, i := range def.Devices.Interfaces {
+		if (i.Source != nil && i.Source.Bridge != nil && i.Source.Bridge.Bridge == d.BridgeName) ||
+			(i.Source != nil && i.Source.Direct != nil && i.Source.Direct.Dev == d.BridgeName) {
+			log.Debugf("domain %s already has a %s interface attached to %s", d.MachineName, d.NetworkMode, d.BridgeName)
+			return nil
+		}
+	}
+
+	flags := libvirt.DOMAIN_DEVICE_MODIFY_CONFIG
+	if active, err := dom.IsActive(); err == nil && active {
+		flags |= libvirt.DOMAIN_DEVICE_MODIFY_LIVE
+	}
+	if err := dom.AttachDeviceFlags(ifaceXML, flags); err != nil {
+		return errors.Wrapf(err, "attaching %s interface to domain %s", d.NetworkMode, d.MachineName)
+	}
+	log.Debugf("attached %s interface (%s) to domain %s", d.NetworkMode, d.BridgeName, d.MachineName)
+	return nil
+}
+
 // setupNetwork ensures that the network with `name` is started (active)
 // and has the autostart feature set.
 func setupNetwork(conn *libvirt.Connect, name string) error {
@@ -101,6 +204,15 @@ func (d *Driver) ensureNetwork() error {
 	}
 	defer conn.Close()
 
+	if NetworkMode(d.NetworkMode).usesHostNetwork() {
+		// bridge/macvtap modes attach the domain's interface directly to a
+		// host device, carrying d.PrivateMAC itself, so there is no
+		// minikube-managed libvirt network (neither "default" nor private
+		// NAT) to create or ensure at all in this mode.
+		log.Infof("Using host network %s in %s mode, skipping NAT network setup", d.BridgeName, d.NetworkMode)
+		return d.ensurePublicInterface(conn)
+	}
+
 	// network: default
 
 	// It is assumed that the libvirt/kvm installation has already created this network
@@ -135,6 +247,13 @@ func (d *Driver) ensureNetwork() error {
 
 // createNetwork is called during creation of the VM only (and not on start)
 func (d *Driver) createNetwork() error {
+	if NetworkMode(d.NetworkMode).usesHostNetwork() {
+		// bridge/macvtap modes attach directly to an existing host bridge or
+		// device, so there is no NAT network to create at all.
+		log.Infof("Using host network %s in %s mode, skipping private KVM network creation", d.BridgeName, d.NetworkMode)
+		return nil
+	}
+
 	if d.Network == defaultPrivateNetworkName {
 		return fmt.Errorf("KVM network can't be named %s. This is the name of the private network created by minikube", defaultPrivateNetworkName)
 	}
@@ -166,6 +285,11 @@ func (d *Driver) createNetwork() error {
 	}()
 	if err == nil {
 		log.Debugf("found existing private KVM network %s", d.PrivateNetwork)
+		// the network already exists, but may predate d.PrivateMAC (e.g. it
+		// was left behind by a previous VM): make sure our reservation is present.
+		if err := d.ensureHostReservation(netp); err != nil {
+			log.Debugf("failed to reserve a static IP for %s on existing network %s: %v", d.PrivateMAC, d.PrivateNetwork, err)
+		}
 		return nil
 	}
 
@@ -179,21 +303,42 @@ func (d *Driver) createNetwork() error {
 			log.Debugf("failed to find free subnet for private KVM network %s after %d attempts: %v", d.PrivateNetwork, 20, err)
 			return fmt.Errorf("un-retryable: %w", err)
 		}
-		// create the XML for the private network from our networkTmpl
-		tryNet := kvmNetwork{
-			Name:       d.PrivateNetwork,
-			Parameters: *subnet,
+		// pin the VM to a predictable address within the subnet so lookupIP
+		// doesn't have to race the DHCP lease showing up in the status file
+		var hostIP string
+		hostIP, err = d.reservedHostIP(subnet)
+		if err != nil {
+			return fmt.Errorf("computing reserved host IP for %s: %w", d.PrivateNetwork, err)
 		}
-		tmpl := template.Must(template.New("network").Parse(networkTmpl))
-		var networkXML bytes.Buffer
-		if err = tmpl.Execute(&networkXML, tryNet); err != nil {
-			return fmt.Errorf("executing private KVM network template: %w", err)
+		// build the typed definition for the private network
+		tryNet := &libvirtxml.Network{
+			Name: d.PrivateNetwork,
+			DNS:  &libvirtxml.NetworkDNS{Enable: "no"},
+			IPs: []libvirtxml.NetworkIP{
+				{
+					Address: subnet.Gateway,
+					Netmask: subnet.Netmask,
+					DHCP: &libvirtxml.NetworkDHCP{
+						Ranges: []libvirtxml.NetworkDHCPRange{
+							{Start: subnet.ClientMin, End: subnet.ClientMax},
+						},
+						Hosts: []libvirtxml.NetworkDHCPHost{
+							{MAC: d.PrivateMAC, IP: hostIP},
+						},
+					},
+				},
+			},
+		}
+		var networkXML string
+		networkXML, err = tryNet.Marshal()
+		if err != nil {
+			return fmt.Errorf("marshalling private KVM network %s: %w", d.PrivateNetwork, err)
 		}
-		// define the network using our template
+		// define the network from the marshalled XML
 		var network *libvirt.Network
-		network, err = conn.NetworkDefineXML(networkXML.String())
+		network, err = conn.NetworkDefineXML(networkXML)
 		if err != nil {
-			return fmt.Errorf("defining private KVM network %s %s from xml %s: %w", d.PrivateNetwork, subnet.CIDR, networkXML.String(), err)
+			return fmt.Errorf("defining private KVM network %s %s from xml %s: %w", d.PrivateNetwork, subnet.CIDR, networkXML, err)
 		}
 		// and finally create & start it
 		log.Debugf("trying to create private KVM network %s %s...", d.PrivateNetwork, subnet.CIDR)
@@ -207,7 +352,76 @@ func (d *Driver) createNetwork() error {
 	return fmt.Errorf("failed to create private KVM network %s: %w", d.PrivateNetwork, err)
 }
 
+// ensureHostReservation makes sure n has a live DHCP host reservation
+// pinning d.PrivateMAC to its predictable address, adding one via
+// reserveHostInNetwork if it's missing. Used for networks that already
+// existed before d.PrivateMAC was known to createNetwork.
+func (d *Driver) ensureHostReservation(n *libvirt.Network) error {
+	xmlDesc, err := n.GetXMLDesc(0)
+	if err != nil {
+		return errors.Wrap(err, "getting network xml")
+	}
+	def := libvirtxml.Network{}
+	if err := def.Unmarshal(xmlDesc); err != nil {
+		return errors.Wrap(err, "unmarshalling network xml")
+	}
+	if len(def.IPs) == 0 || def.IPs[0].DHCP == nil {
+		return fmt.Errorf("network %s has no configured DHCP range", d.PrivateNetwork)
+	}
+
+	for _, host := range def.IPs[0].DHCP.Hosts {
+		if strings.EqualFold(host.MAC, d.PrivateMAC) {
+			// reservation already present
+			return nil
+		}
+	}
+
+	subnet := &network.Parameters{Gateway: def.IPs[0].Address}
+	hostIP, err := d.reservedHostIP(subnet)
+	if err != nil {
+		return err
+	}
+	return d.reserveHostInNetwork(n, d.PrivateMAC, hostIP)
+}
+
+// reserveHostInNetwork adds a live static DHCP host reservation (mac -> ip)
+// to an already-defined, possibly already-running network, so the
+// reservation survives without having to recreate the network.
+func (d *Driver) reserveHostInNetwork(n *libvirt.Network, mac, ip string) error {
+	// NetworkDHCPHost has no top-level Marshal method of its own (only
+	// libvirtxml's root document types do), so build the <host> fragment
+	// virNetworkUpdate expects directly.
+	hostXML := fmt.Sprintf("<host mac=%q ip=%q/>", mac, ip)
+
+	// libvirt rejects NETWORK_UPDATE_AFFECT_LIVE when the network isn't
+	// currently running, so only request it when the network is active -
+	// same idea as ensurePublicInterface checking dom.IsActive().
+	flags := libvirt.NETWORK_UPDATE_AFFECT_CONFIG
+	if active, err := n.IsActive(); err == nil && active {
+		flags |= libvirt.NETWORK_UPDATE_AFFECT_LIVE
+	}
+
+	err := n.Update(
+		libvirt.NETWORK_UPDATE_COMMAND_ADD_LAST,
+		libvirt.NETWORK_SECTION_IP_DHCP_HOST,
+		-1,
+		hostXML,
+		flags,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "reserving %s for %s on network %s", ip, mac, d.PrivateNetwork)
+	}
+	log.Debugf("reserved %s for %s on network %s", ip, mac, d.PrivateNetwork)
+	return nil
+}
+
 func (d *Driver) deleteNetwork() error {
+	if NetworkMode(d.NetworkMode).usesHostNetwork() {
+		// no NAT network was ever created for this domain in bridge/macvtap mode
+		log.Debugf("Using host network %s in %s mode, nothing to delete", d.BridgeName, d.NetworkMode)
+		return nil
+	}
+
 	conn, err := getConnection(d.ConnectionURI)
 	if err != nil {
 		return errors.Wrap(err, "getting libvirt connection")
@@ -261,20 +475,6 @@ func (d *Driver) deleteNetwork() error {
 }
 
 func (d *Driver) checkDomains(conn *libvirt.Connect) error {
-	type source struct {
-		// XMLName xml.Name `xml:"source"`
-		Network string `xml:"network,attr"`
-	}
-	type iface struct {
-		// XMLName xml.Name `xml:"interface"`
-		Source source `xml:"source"`
-	}
-	type result struct {
-		// XMLName xml.Name `xml:"domain"`
-		Name       string  `xml:"name"`
-		Interfaces []iface `xml:"devices>interface"`
-	}
-
 	// iterate over every (also turned off) domains, and check if it
 	// is using the private network. Do *not* delete the network if
 	// that is the case
@@ -315,16 +515,19 @@ func (d *Driver) checkDomains(conn *libvirt.Connect) error {
 		}
 		log.Debugf("Got XML for domain %s", name)
 
-		v := result{}
-		err = xml.Unmarshal([]byte(xmlString), &v)
+		v := libvirtxml.Domain{}
+		err = v.Unmarshal(xmlString)
 		if err != nil {
 			return errors.Wrapf(err, "failed to unmarshal XML of domain '%s", name)
 		}
 		log.Debugf("Unmarshaled XML for domain %s: %#v", name, v)
 
 		// iterate over the found interfaces
-		for _, i := range v.Interfaces {
-			if i.Source.Network == d.PrivateNetwork {
+		for _, i := range v.Devices.Interfaces {
+			if i.Source == nil || i.Source.Network == nil {
+				continue
+			}
+			if i.Source.Network.Network == d.PrivateNetwork {
 				log.Debugf("domain %s DOES use network %s, aborting...", name, d.PrivateNetwork)
 				return fmt.Errorf("network still in use at least by domain '%s',", name)
 			}
@@ -335,6 +538,10 @@ func (d *Driver) checkDomains(conn *libvirt.Connect) error {
 	return nil
 }
 
+// lookupIPTimeout bounds each of the fallback IP discovery paths below, so a
+// hung guest agent or a slow libvirt call can't stall the whole lookup.
+const lookupIPTimeout = 5 * time.Second
+
 func (d *Driver) lookupIP() (string, error) {
 	conn, err := getConnection(d.ConnectionURI)
 	if err != nil {
@@ -342,18 +549,194 @@ func (d *Driver) lookupIP() (string, error) {
 	}
 	defer conn.Close()
 
+	// In bridge/macvtap mode there is no minikube-managed dnsmasq, so the
+	// status/leases files never exist: go straight to the guest agent and
+	// fall back to scanning the bridge's ARP table.
+	if NetworkMode(d.NetworkMode).usesHostNetwork() {
+		ip, err := d.lookupIPFromGuestAgent(conn)
+		if err == nil && ip != "" {
+			log.Debugf("Found IP %s for MAC %s via guest agent", ip, d.PrivateMAC)
+			return ip, nil
+		}
+		log.Debugf("Failed to get IP via guest agent, falling back to ARP scan of %s: %v", d.BridgeName, err)
+
+		ip, err = d.lookupIPFromARPScan()
+		if err != nil {
+			return "", errors.Wrapf(err, "scanning %s for MAC %s", d.BridgeName, d.PrivateMAC)
+		}
+		return ip, nil
+	}
+
 	libVersion, err := conn.GetLibVersion()
 	if err != nil {
 		return "", errors.Wrap(err, "getting libversion")
 	}
 
 	// Earlier versions of libvirt use a lease file instead of a status file
+	var ip string
 	if libVersion < 1002006 {
-		return d.lookupIPFromLeasesFile()
+		ip, err = d.lookupIPFromLeasesFile()
+	} else {
+		// TODO: for everything > 1002006, there is direct support in the libvirt-go for handling this
+		ip, err = d.lookupIPFromStatusFile(conn)
+	}
+	if err == nil && ip != "" {
+		return ip, nil
 	}
+	log.Debugf("IP not found via dnsmasq status/leases file, falling back to guest agent and DHCP leases: %v", err)
 
-	// TODO: for everything > 1002006, there is direct support in the libvirt-go for handling this
-	return d.lookupIPFromStatusFile(conn)
+	// Fall back to asking the guest directly via qemu-guest-agent, which
+	// sidesteps the dnsmasq status file race entirely on slow boots.
+	ip, err = d.lookupIPFromGuestAgent(conn)
+	if err == nil && ip != "" {
+		log.Debugf("Found IP %s for MAC %s via guest agent", ip, d.PrivateMAC)
+		return ip, nil
+	}
+	log.Debugf("Failed to get IP via guest agent, falling back to DHCP leases: %v", err)
+
+	// Last resort: walk every network the domain is attached to and match
+	// its DHCP leases against our MAC.
+	ip, err = d.lookupIPFromNetworkLeases(conn)
+	if err == nil && ip != "" {
+		log.Debugf("Found IP %s for MAC %s via network DHCP leases", ip, d.PrivateMAC)
+		return ip, nil
+	}
+	log.Debugf("Failed to get IP via network DHCP leases: %v", err)
+
+	return "", fmt.Errorf("failed to find IP for MAC %s via status file, guest agent, or DHCP leases", d.PrivateMAC)
+}
+
+// lookupIPFromGuestAgent asks the domain's qemu-guest-agent for its interface
+// addresses. This requires the agent to be installed and running in the
+// guest (minikube's ISO can ship it), but avoids any dependence on dnsmasq
+// state files, so it works even with bridge/macvtap networking.
+func (d *Driver) lookupIPFromGuestAgent(conn *libvirt.Connect) (string, error) {
+	dom, err := conn.LookupDomainByName(d.MachineName)
+	if err != nil {
+		return "", errors.Wrap(err, "looking up domain by name")
+	}
+
+	type result struct {
+		ifaces []libvirt.DomainInterface
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		// dom is only safe to free once this call has actually returned, so
+		// free it here rather than in a defer in the caller: on timeout, the
+		// caller moves on while this goroutine may still be blocked inside
+		// the cgo call, and freeing the handle out from under it would be a
+		// use-after-free on the underlying C object.
+		defer func() { _ = dom.Free() }()
+		ifaces, err := dom.InterfaceAddresses(libvirt.DOMAIN_INTERFACE_ADDRESSES_SRC_AGENT, 0)
+		done <- result{ifaces: ifaces, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return "", errors.Wrap(r.err, "getting interface addresses from guest agent")
+		}
+		return ipForMACFromInterfaces(d.PrivateMAC, r.ifaces), nil
+	case <-time.After(lookupIPTimeout):
+		return "", fmt.Errorf("timed out after %s waiting for guest agent", lookupIPTimeout)
+	}
+}
+
+// ipForMACFromInterfaces finds the first address belonging to the interface
+// whose hardware address matches mac.
+func ipForMACFromInterfaces(mac string, ifaces []libvirt.DomainInterface) string {
+	for _, iface := range ifaces {
+		if !strings.EqualFold(iface.Hwaddr, mac) {
+			continue
+		}
+		for _, addr := range iface.Addrs {
+			if addr.Addr != "" {
+				return addr.Addr
+			}
+		}
+	}
+	return ""
+}
+
+// lookupIPFromNetworkLeases iterates every libvirt network the domain is
+// attached to, in the order its interfaces appear in the domain XML, and
+// returns the first DHCP lease matching our MAC; no interface->IP map is
+// built. Only interfaces whose source is a libvirt network are considered
+// (a host bridge or macvtap device is not). This covers networks (such as
+// "default") whose leases aren't in the private network's dnsmasq status
+// file that minikube otherwise reads directly.
+func (d *Driver) lookupIPFromNetworkLeases(conn *libvirt.Connect) (string, error) {
+	dom, err := conn.LookupDomainByName(d.MachineName)
+	if err != nil {
+		return "", errors.Wrap(err, "looking up domain by name")
+	}
+	defer func() { _ = dom.Free() }()
+
+	xmlDesc, err := dom.GetXMLDesc(0)
+	if err != nil {
+		return "", errors.Wrap(err, "getting domain xml")
+	}
+
+	dmn := libvirtxml.Domain{}
+	if err := dmn.Unmarshal(xmlDesc); err != nil {
+		return "", errors.Wrap(err, "unmarshalling domain xml")
+	}
+
+	for _, i := range dmn.Devices.Interfaces {
+		if i.Source == nil || i.Source.Network == nil || i.Source.Network.Network == "" {
+			continue
+		}
+		netName := i.Source.Network.Network
+		ip, err := d.ipFromNetworkLeases(conn, netName)
+		if err != nil {
+			log.Debugf("Failed to get DHCP leases for network %s: %v", netName, err)
+			continue
+		}
+		if ip != "" {
+			return ip, nil
+		}
+	}
+
+	return "", nil
+}
+
+// ipFromNetworkLeases looks up a single named network's DHCP leases for our
+// private MAC address, bounded by lookupIPTimeout.
+func (d *Driver) ipFromNetworkLeases(conn *libvirt.Connect, name string) (string, error) {
+	network, err := conn.LookupNetworkByName(name)
+	if err != nil {
+		return "", errors.Wrapf(err, "looking up network %s", name)
+	}
+
+	type result struct {
+		leases []libvirt.NetworkDHCPLease
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		// See the matching comment in lookupIPFromGuestAgent: free the
+		// handle from within the goroutine that actually finishes using it,
+		// not from a caller-side defer that can run concurrently with it.
+		defer func() { _ = network.Free() }()
+		leases, err := network.GetDHCPLeases()
+		done <- result{leases: leases, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return "", errors.Wrapf(r.err, "getting DHCP leases for network %s", name)
+		}
+		for _, lease := range r.leases {
+			if strings.EqualFold(lease.Mac, d.PrivateMAC) {
+				return lease.IPaddr, nil
+			}
+		}
+		return "", nil
+	case <-time.After(lookupIPTimeout):
+		return "", fmt.Errorf("timed out after %s waiting for DHCP leases on network %s", lookupIPTimeout, name)
+	}
 }
 
 func (d *Driver) lookupIPFromStatusFile(conn *libvirt.Connect) (string, error) {
@@ -426,3 +809,129 @@ func (d *Driver) lookupIPFromLeasesFile() (string, error) {
 	}
 	return ipAddress, nil
 }
+
+// arpScanMaxHosts bounds how many addresses probeARP will ever arping, so a
+// large bridge subnet (up to the /16 that bridgeHostAddrs still enumerates)
+// can't turn a lookup into tens of thousands of probes.
+const arpScanMaxHosts = 1024
+
+// lookupIPFromARPScan actively probes the host addresses in the bridge's
+// subnet via arping (see probeARP for the limits), then reads the kernel's ARP table for an entry matching
+// our MAC address. The active probe matters because on a freshly booted VM
+// nothing has talked to the guest yet, so the host's ARP table has no entry
+// for it to passively discover; it's used in bridge/macvtap network modes,
+// where the VM's address is handed out by an external DHCP server on the
+// host LAN and minikube has no dnsmasq status file to consult.
+func (d *Driver) lookupIPFromARPScan() (string, error) {
+	if err := d.probeARP(); err != nil {
+		log.Debugf("failed to fully probe %s for %s, reading ARP table anyway: %v", d.BridgeName, d.PrivateMAC, err)
+	}
+
+	table, err := ioutil.ReadFile("/proc/net/arp")
+	if err != nil {
+		return "", errors.Wrap(err, "reading arp table")
+	}
+
+	// header: IP address, HW type, Flags, HW address, Mask, Device
+	for i, line := range strings.Split(string(table), "\n") {
+		if i == 0 || len(line) == 0 {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			continue
+		}
+		ip, mac, dev := fields[0], fields[3], fields[5]
+		if strings.EqualFold(mac, d.PrivateMAC) && dev == d.BridgeName {
+			return ip, nil
+		}
+	}
+
+	return "", fmt.Errorf("no ARP entry for MAC %s on %s", d.PrivateMAC, d.BridgeName)
+}
+
+// probeARP sends an ARP request (via arping) to the host addresses in
+// d.BridgeName's subnet, in parallel, so a guest that booted too recently to
+// have generated any of its own traffic still gets an entry populated in the
+// host's ARP table for lookupIPFromARPScan to read. Only the first
+// arpScanMaxHosts addresses are probed, and subnets larger than /16 are not
+// probed at all (see bridgeHostAddrs).
+func (d *Driver) probeARP() error {
+	hosts, err := bridgeHostAddrs(d.BridgeName)
+	if err != nil {
+		return errors.Wrapf(err, "enumerating addresses on %s", d.BridgeName)
+	}
+	if len(hosts) > arpScanMaxHosts {
+		log.Debugf("subnet on %s has %d hosts, only probing the first %d", d.BridgeName, len(hosts), arpScanMaxHosts)
+		hosts = hosts[:arpScanMaxHosts]
+	}
+
+	var wg sync.WaitGroup
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), lookupIPTimeout)
+			defer cancel()
+			// we only care about provoking an ARP reply into the kernel's
+			// table, not about arping's own exit status.
+			_ = exec.CommandContext(ctx, "arping", "-c", "1", "-w", "1", "-I", d.BridgeName, host).Run()
+		}(host)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// bridgeHostAddrs lists every host address (excluding network/broadcast) in
+// the subnets assigned to the given interface.
+func bridgeHostAddrs(iface string) ([]string, error) {
+	ifc, err := net.InterfaceByName(iface)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up interface %s", iface)
+	}
+	addrs, err := ifc.Addrs()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting addresses for %s", iface)
+	}
+
+	var hosts []string
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.To4() == nil {
+			continue
+		}
+		ones, bits := ipNet.Mask.Size()
+		if bits-ones > 16 {
+			log.Debugf("skipping ARP probe of %s: %s is too large to scan", iface, ipNet)
+			continue
+		}
+		netAddr := ipNet.IP.Mask(ipNet.Mask).To4()
+		ip := make(net.IP, len(netAddr))
+		copy(ip, netAddr)
+		for ; ipNet.Contains(ip); incIP(ip) {
+			next := make(net.IP, len(ip))
+			copy(next, ip)
+			incIP(next)
+			if ip.Equal(netAddr) || !ipNet.Contains(next) || ip.Equal(ipNet.IP) {
+				// skip the network address, the broadcast address (the last
+				// one in the subnet) and the bridge's own address
+				continue
+			}
+			host := make(net.IP, len(ip))
+			copy(host, ip)
+			hosts = append(hosts, host.String())
+		}
+	}
+	return hosts, nil
+}
+
+// incIP increments ip in place, treating it as a big-endian integer.
+func incIP(ip net.IP) {
+	for i := len(ip) - 1; i >= 0; i-- {
+		ip[i]++
+		if ip[i] != 0 {
+			break
+		}
+	}
+}